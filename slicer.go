@@ -16,19 +16,27 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 )
 
+// ExtURIPivotSlicerCachesX15 is the extension URI used to register a pivot
+// table slicer cache under the workbook's x15:slicerCaches extension list,
+// in addition to the ExtURISlicerCachesX15 entry every slicer cache gets.
+const ExtURIPivotSlicerCachesX15 = "{2F2917AC-028F-4AD1-BAA1-7AF98735234E}"
+
 // SlicerOptions represents the settings of the slicer.
 //
 // Name specifies the slicer name, should be an existing field name of the given
 // table or pivot table, this setting is required.
 //
 // Table specifies the name of the table or pivot table, this setting is
-// required.
+// required. Both regular tables and pivot tables (as returned by
+// GetPivotTables) are supported. For a pivot table, ItemDesc is not applied
+// and the items keep their default order.
 //
 // Cell specifies the left top cell coordinates the position for inserting the
 // slicer, this setting is required.
@@ -49,6 +57,18 @@ import (
 // and the default setting is false (represents ascending).
 //
 // Format specifies the format of the slicer, this setting is optional.
+//
+// Style specifies the built-in or custom style name of the slicer, this
+// setting is optional. The built-in style names are
+// SlicerStyleLight1-SlicerStyleLight6, SlicerStyleDark1-SlicerStyleDark6,
+// and SlicerStyleOther1-SlicerStyleOther2. A custom style name registered
+// with AddSlicerStyle may also be used.
+//
+// PivotTables specifies additional pivot tables that the slicer should be
+// connected to, besides the pivot table given by Table, so that a single
+// selection filters all of them. Each entry should be a fully-qualified
+// "Sheet!Name" reference, as returned by GetPivotTables. This setting is
+// optional, and only applies when Table refers to a pivot table.
 type SlicerOptions struct {
 	Name          string
 	Table         string
@@ -60,10 +80,40 @@ type SlicerOptions struct {
 	DisplayHeader *bool
 	ItemDesc      bool
 	Format        GraphicOptions
+	Style         string
+	PivotTables   []string
+}
+
+// Built-in slicer style names that can be assigned to SlicerOptions.Style.
+const (
+	SlicerStyleLight1 = "SlicerStyleLight1"
+	SlicerStyleLight2 = "SlicerStyleLight2"
+	SlicerStyleLight3 = "SlicerStyleLight3"
+	SlicerStyleLight4 = "SlicerStyleLight4"
+	SlicerStyleLight5 = "SlicerStyleLight5"
+	SlicerStyleLight6 = "SlicerStyleLight6"
+	SlicerStyleDark1  = "SlicerStyleDark1"
+	SlicerStyleDark2  = "SlicerStyleDark2"
+	SlicerStyleDark3  = "SlicerStyleDark3"
+	SlicerStyleDark4  = "SlicerStyleDark4"
+	SlicerStyleDark5  = "SlicerStyleDark5"
+	SlicerStyleDark6  = "SlicerStyleDark6"
+	SlicerStyleOther1 = "SlicerStyleOther1"
+	SlicerStyleOther2 = "SlicerStyleOther2"
+)
+
+// builtinSlicerStyles lists the built-in slicer style names accepted by
+// SlicerOptions.Style, in addition to any custom style name registered with
+// AddSlicerStyle.
+var builtinSlicerStyles = []string{
+	SlicerStyleLight1, SlicerStyleLight2, SlicerStyleLight3, SlicerStyleLight4, SlicerStyleLight5, SlicerStyleLight6,
+	SlicerStyleDark1, SlicerStyleDark2, SlicerStyleDark3, SlicerStyleDark4, SlicerStyleDark5, SlicerStyleDark6,
+	SlicerStyleOther1, SlicerStyleOther2,
 }
 
 // AddSlicer function inserts a slicer by giving the worksheet name and slicer
-// settings. The pivot table slicer is not supported currently.
+// settings. Both table slicers and pivot table slicers are supported, opts.Table
+// may reference either a table name or a pivot table name.
 //
 // For example, insert a slicer on the Sheet1!E1 with field Column1 for the
 // table named Table1:
@@ -77,11 +127,11 @@ type SlicerOptions struct {
 //	    Height:  200,
 //	})
 func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
-	opts, err := parseSlicerOptions(opts)
+	opts, err := f.parseSlicerOptions(opts)
 	if err != nil {
 		return err
 	}
-	table, colIdx, err := f.getSlicerSource(sheet, opts)
+	table, pivotTable, colIdx, err := f.getSlicerSource(sheet, opts)
 	if err != nil {
 		return err
 	}
@@ -89,11 +139,11 @@ func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
 	if err != nil {
 		return err
 	}
-	slicerCacheName, err := f.setSlicerCache(colIdx, opts, table)
+	slicerCacheName, err := f.setSlicerCache(sheet, colIdx, opts, table, pivotTable)
 	if err != nil {
 		return err
 	}
-	slicerName, err := f.addDrawingSlicer(sheet, opts)
+	slicerName, err := f.addDrawingSlicer(sheet, opts, pivotTable != nil)
 	if err != nil {
 		return err
 	}
@@ -103,12 +153,13 @@ func (f *File) AddSlicer(sheet string, opts *SlicerOptions) error {
 		Caption:     opts.Caption,
 		ShowCaption: opts.DisplayHeader,
 		RowHeight:   251883,
+		Style:       opts.Style,
 	})
 }
 
 // parseSlicerOptions provides a function to parse the format settings of the
 // slicer with default value.
-func parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
+func (f *File) parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
 	if opts == nil {
 		return nil, ErrParameterRequired
 	}
@@ -133,6 +184,13 @@ func parseSlicerOptions(opts *SlicerOptions) (*SlicerOptions, error) {
 	if opts.Format.ScaleY == 0 {
 		opts.Format.ScaleY = defaultDrawingScale
 	}
+	if opts.Style != "" && inStrSlice(builtinSlicerStyles, opts.Style, true) == -1 {
+		if ok, err := f.hasSlicerStyle(opts.Style); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, newInvalidSlicerStyleError(opts.Style)
+		}
+	}
 	return opts, nil
 }
 
@@ -162,17 +220,18 @@ func (f *File) countSlicerCache() int {
 	return count
 }
 
-// getSlicerSource returns the slicer data source table or pivot table settings
-// and the index of the given slicer fields in the table or pivot table
-// column.
-func (f *File) getSlicerSource(sheet string, opts *SlicerOptions) (*Table, int, error) {
+// getSlicerSource returns the slicer data source table or pivot table
+// settings and the index of the given slicer fields in the table or pivot
+// table column. When no table in the worksheet matches opts.Table, the
+// pivot tables of the worksheet are searched instead.
+func (f *File) getSlicerSource(sheet string, opts *SlicerOptions) (*Table, *PivotTableOptions, int, error) {
 	var (
 		table       *Table
 		colIdx      int
 		tables, err = f.GetTables(sheet)
 	)
 	if err != nil {
-		return table, colIdx, err
+		return table, nil, colIdx, err
 	}
 	for _, tbl := range tables {
 		if tbl.Name == opts.Table {
@@ -180,14 +239,37 @@ func (f *File) getSlicerSource(sheet string, opts *SlicerOptions) (*Table, int,
 			break
 		}
 	}
-	if table == nil {
-		return table, colIdx, newNoExistTableError(opts.Table)
+	if table != nil {
+		order, _ := f.getTableFieldsOrder(sheet, fmt.Sprintf("%s!%s", sheet, table.Range))
+		if colIdx = inStrSlice(order, opts.Name, true); colIdx == -1 {
+			return table, nil, colIdx, newInvalidSlicerNameError(opts.Name)
+		}
+		return table, nil, colIdx, err
 	}
-	order, _ := f.getTableFieldsOrder(sheet, fmt.Sprintf("%s!%s", sheet, table.Range))
-	if colIdx = inStrSlice(order, opts.Name, true); colIdx == -1 {
-		return table, colIdx, newInvalidSlicerNameError(opts.Name)
+	pivotTables, err := f.GetPivotTables(sheet)
+	if err != nil {
+		return table, nil, colIdx, err
 	}
-	return table, colIdx, err
+	for _, pivotTable := range pivotTables {
+		if pivotTable.Name != opts.Table {
+			continue
+		}
+		if pivotTable.DataRange != "" {
+			if order, orderErr := f.getTableFieldsOrder(sheet, pivotTable.DataRange); orderErr == nil {
+				if idx := inStrSlice(order, opts.Name, true); idx != -1 {
+					return nil, &pivotTable, idx, nil
+				}
+			}
+		}
+		fields := append(append(append([]PivotTableField{}, pivotTable.RowFields...), pivotTable.ColFields...), pivotTable.DataFields...)
+		for idx, field := range fields {
+			if field.Data == opts.Name {
+				return nil, &pivotTable, idx, nil
+			}
+		}
+		return nil, nil, colIdx, newInvalidSlicerNameError(opts.Name)
+	}
+	return nil, nil, colIdx, newNoExistTableError(opts.Table)
 }
 
 // addSheetSlicer adds a new slicer and updates the namespace and relationships
@@ -314,9 +396,9 @@ func (f *File) genSlicerCacheName(name string) string {
 }
 
 // setSlicerCache check if a slicer cache already exists or add a new slicer
-// cache by giving the column index, slicer, table options, and returns the
-// slicer cache name.
-func (f *File) setSlicerCache(colIdx int, opts *SlicerOptions, table *Table) (string, error) {
+// cache by giving the column index, slicer, table or pivot table options,
+// and returns the slicer cache name.
+func (f *File) setSlicerCache(sheet string, colIdx int, opts *SlicerOptions, table *Table, pivotTable *PivotTableOptions) (string, error) {
 	var ok bool
 	var slicerCacheName string
 	f.Pkg.Range(func(k, v interface{}) bool {
@@ -331,7 +413,7 @@ func (f *File) setSlicerCache(colIdx int, opts *SlicerOptions, table *Table) (st
 			}
 			ext := new(xlsxExt)
 			_ = f.xmlNewDecoder(strings.NewReader(slicerCache.ExtLst.Ext)).Decode(ext)
-			if ext.URI == ExtURISlicerCacheDefinition {
+			if table != nil && ext.URI == ExtURISlicerCacheDefinition {
 				tableSlicerCache := new(decodeTableSlicerCache)
 				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(tableSlicerCache)
 				if tableSlicerCache.TableID == table.tID && tableSlicerCache.Column == colIdx+1 {
@@ -339,6 +421,16 @@ func (f *File) setSlicerCache(colIdx int, opts *SlicerOptions, table *Table) (st
 					return false
 				}
 			}
+			if pivotTable != nil && ext.URI == ExtURISlicerCacheDefinition {
+				pivotSlicerCache := new(decodePivotTableSlicerCache)
+				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(pivotSlicerCache)
+				for _, pt := range pivotSlicerCache.PivotTables {
+					if pt.Name == pivotTable.Name && pivotSlicerCache.FieldIndex == colIdx {
+						ok, slicerCacheName = true, slicerCache.Name
+						return false
+					}
+				}
+			}
 		}
 		return true
 	})
@@ -346,7 +438,7 @@ func (f *File) setSlicerCache(colIdx int, opts *SlicerOptions, table *Table) (st
 		return slicerCacheName, nil
 	}
 	slicerCacheName = f.genSlicerCacheName(opts.Name)
-	return slicerCacheName, f.addSlicerCache(slicerCacheName, colIdx, opts, table)
+	return slicerCacheName, f.addSlicerCache(sheet, slicerCacheName, colIdx, opts, table, pivotTable)
 }
 
 // slicerReader provides a function to get the pointer to the structure
@@ -367,14 +459,15 @@ func (f *File) slicerReader(slicerXML string) (*xlsxSlicers, error) {
 	return slicer, nil
 }
 
-// addSlicerCache adds a new slicer cache by giving the slicer cache name,
-// column index, slicer, and table options.
-func (f *File) addSlicerCache(slicerCacheName string, colIdx int, opts *SlicerOptions, table *Table) error {
+// addSlicerCache adds a new slicer cache by giving the worksheet name,
+// slicer cache name, column index, slicer, and table or pivot table
+// options.
+func (f *File) addSlicerCache(sheet, slicerCacheName string, colIdx int, opts *SlicerOptions, table *Table, pivotTable *PivotTableOptions) error {
 	var (
-		slicerCacheBytes, tableSlicerBytes, extLstBytes []byte
-		slicerCacheID                                   = f.countSlicerCache() + 1
-		decodeExtLst                                    = new(decodeExtLst)
-		slicerCache                                     = xlsxSlicerCacheDefinition{
+		slicerCacheBytes, sourceBytes, extLstBytes []byte
+		slicerCacheID                              = f.countSlicerCache() + 1
+		decodeExtLst                               = new(decodeExtLst)
+		slicerCache                                = xlsxSlicerCacheDefinition{
 			XMLNSXMC:   SourceRelationshipCompatibility.Value,
 			XMLNSX:     NameSpaceSpreadSheet.Value,
 			XMLNSX15:   NameSpaceSpreadSheetX15.Value,
@@ -384,18 +477,30 @@ func (f *File) addSlicerCache(slicerCacheName string, colIdx int, opts *SlicerOp
 			ExtLst:     &xlsxExtLst{},
 		}
 	)
-	var sortOrder string
-	if opts.ItemDesc {
-		sortOrder = "descending"
-	}
-	tableSlicerBytes, _ = xml.Marshal(&xlsxTableSlicerCache{
-		TableID:   table.tID,
-		Column:    colIdx + 1,
-		SortOrder: sortOrder,
-	})
+	if pivotTable != nil {
+		pivotTableRefs := []xlsxPivotTableSlicerCacheRef{{TabID: f.getSheetID(sheet), Name: pivotTable.Name}}
+		extraRefs, err := f.resolveSlicerPivotTableRefs(opts.PivotTables, pivotTable.Name)
+		if err != nil {
+			return err
+		}
+		sourceBytes, _ = xml.Marshal(&xlsxPivotTableSlicerCache{
+			PivotTables: append(pivotTableRefs, extraRefs...),
+			FieldIndex:  colIdx,
+		})
+	} else {
+		var sortOrder string
+		if opts.ItemDesc {
+			sortOrder = "descending"
+		}
+		sourceBytes, _ = xml.Marshal(&xlsxTableSlicerCache{
+			TableID:   table.tID,
+			Column:    colIdx + 1,
+			SortOrder: sortOrder,
+		})
+	}
 	decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
 		xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
-		URI:   ExtURISlicerCacheDefinition, Content: string(tableSlicerBytes),
+		URI:   ExtURISlicerCacheDefinition, Content: string(sourceBytes),
 	})
 	extLstBytes, _ = xml.Marshal(decodeExtLst)
 	slicerCache.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
@@ -408,12 +513,66 @@ func (f *File) addSlicerCache(slicerCacheName string, colIdx int, opts *SlicerOp
 	if err := f.addWorkbookSlicerCache(slicerCacheID, ExtURISlicerCachesX15); err != nil {
 		return err
 	}
+	if pivotTable != nil {
+		if err := f.addWorkbookSlicerCache(slicerCacheID, ExtURIPivotSlicerCachesX15); err != nil {
+			return err
+		}
+	}
 	return f.SetDefinedName(&DefinedName{Name: slicerCacheName, RefersTo: formulaErrorNA})
 }
 
+// resolveSlicerPivotTableRefs resolves the given "Sheet!Name" pivot table
+// references into pivot cache references, skipping the pivot table that
+// was already added as the slicer's primary data source.
+func (f *File) resolveSlicerPivotTableRefs(refs []string, primaryPivotTable string) ([]xlsxPivotTableSlicerCacheRef, error) {
+	var pivotTableRefs []xlsxPivotTableSlicerCacheRef
+	for _, ref := range refs {
+		sheet, name, err := splitSlicerConnectionRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		if name == primaryPivotTable {
+			continue
+		}
+		pivotTable, err := f.getPivotTableByName(sheet, name)
+		if err != nil {
+			return nil, err
+		}
+		pivotTableRefs = append(pivotTableRefs, xlsxPivotTableSlicerCacheRef{TabID: f.getSheetID(sheet), Name: pivotTable.Name})
+	}
+	return pivotTableRefs, nil
+}
+
+// getPivotTableByName returns the pivot table with the given name on the
+// given worksheet.
+func (f *File) getPivotTableByName(sheet, name string) (*PivotTableOptions, error) {
+	pivotTables, err := f.GetPivotTables(sheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, pivotTable := range pivotTables {
+		if pivotTable.Name == name {
+			pt := pivotTable
+			return &pt, nil
+		}
+	}
+	return nil, newNoExistTableError(name)
+}
+
+// splitSlicerConnectionRef splits a fully-qualified "Sheet!Name" pivot
+// table reference, as returned by GetPivotTables, into its sheet and pivot
+// table name parts.
+func splitSlicerConnectionRef(ref string) (string, string, error) {
+	parts := strings.SplitN(ref, "!", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", newInvalidPivotTableConnectionError(ref)
+	}
+	return parts[0], parts[1], nil
+}
+
 // addDrawingSlicer adds a slicer shape and fallback shape by giving the
 // worksheet name, slicer options, and returns slicer name.
-func (f *File) addDrawingSlicer(sheet string, opts *SlicerOptions) (string, error) {
+func (f *File) addDrawingSlicer(sheet string, opts *SlicerOptions, isPivot bool) (string, error) {
 	var slicerName string
 	drawingID := f.countDrawings() + 1
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
@@ -443,6 +602,10 @@ func (f *File) addDrawingSlicer(sheet string, opts *SlicerOptions) (string, erro
 		},
 	}
 	graphic, _ := xml.Marshal(graphicFrame)
+	fallbackText := "This shape represents a table slicer. Table slicers are not supported in this version of Excel."
+	if isPivot {
+		fallbackText = "This shape represents a pivot table slicer. Pivot table slicers are not supported in this version of Excel."
+	}
 	sp := xdrSp{
 		Macro: opts.Macro,
 		NvSpPr: &xdrNvSpPr{
@@ -454,7 +617,7 @@ func (f *File) addDrawingSlicer(sheet string, opts *SlicerOptions) (string, erro
 			},
 		},
 		SpPr: &xlsxSpPr{
-			Xfrm:      xlsxXfrm{Off: xlsxOff{X: 2914650, Y: 152400}, Ext: aExt{Cx: 1828800, Cy: 2238375}},
+			Xfrm:      xlsxXfrm{Off: xlsxOff{X: 2914650, Y: 152400}, Ext: aExt{Cx: int(opts.Width) * EMU, Cy: int(opts.Height) * EMU}},
 			SolidFill: &xlsxInnerXML{Content: "<a:prstClr val=\"white\"/>"},
 			PrstGeom: xlsxPrstGeom{
 				Prst: "rect",
@@ -464,7 +627,7 @@ func (f *File) addDrawingSlicer(sheet string, opts *SlicerOptions) (string, erro
 		TxBody: &xdrTxBody{
 			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
 			P: []*aP{
-				{R: &aR{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}},
+				{R: &aR{T: fallbackText}},
 				{R: &aR{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}},
 			},
 		},
@@ -518,13 +681,13 @@ func (f *File) addWorkbookSlicerCache(slicerCacheID int, URI string) error {
 		}
 		for idx, ext = range decodeExtLst.Ext {
 			if ext.URI == URI {
-				if URI == ExtURISlicerCachesX15 {
+				if URI == ExtURISlicerCachesX15 || URI == ExtURIPivotSlicerCachesX15 {
 					decodeSlicerCaches = new(decodeX15SlicerCaches)
 					_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeSlicerCaches)
 					slicerCache := xlsxX14SlicerCache{RID: fmt.Sprintf("rId%d", rID)}
 					slicerCacheBytes, _ = xml.Marshal(slicerCache)
 					x15SlicerCaches.Content = decodeSlicerCaches.Content + string(slicerCacheBytes)
-					x15SlicerCaches.XMLNS = NameSpaceSpreadSheetX14.Value
+					x15SlicerCaches.XMLNS = slicerCachesNameSpace(URI)
 					slicerCachesBytes, _ = xml.Marshal(x15SlicerCaches)
 					decodeExtLst.Ext[idx].Content = string(slicerCachesBytes)
 					appendMode = true
@@ -533,19 +696,955 @@ func (f *File) addWorkbookSlicerCache(slicerCacheID int, URI string) error {
 		}
 	}
 	if !appendMode {
-		if URI == ExtURISlicerCachesX15 {
+		if URI == ExtURISlicerCachesX15 || URI == ExtURIPivotSlicerCachesX15 {
 			slicerCache := xlsxX14SlicerCache{RID: fmt.Sprintf("rId%d", rID)}
 			slicerCacheBytes, _ = xml.Marshal(slicerCache)
 			x15SlicerCaches.Content = string(slicerCacheBytes)
-			x15SlicerCaches.XMLNS = NameSpaceSpreadSheetX14.Value
+			x15SlicerCaches.XMLNS = slicerCachesNameSpace(URI)
 			slicerCachesBytes, _ = xml.Marshal(x15SlicerCaches)
 			decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
 				xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
-				URI:   ExtURISlicerCachesX15, Content: string(slicerCachesBytes),
+				URI:   URI, Content: string(slicerCachesBytes),
 			})
 		}
 	}
 	extLstBytes, err = xml.Marshal(decodeExtLst)
 	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
-}
\ No newline at end of file
+}
+
+// slicerCachesNameSpace returns the namespace used for the content of the
+// workbook-level slicer caches extension by the given extension URI: the
+// x15 namespace for ExtURIPivotSlicerCachesX15, which only pivot table
+// slicer caches are registered under, and the x14 namespace otherwise.
+func slicerCachesNameSpace(URI string) string {
+	if URI == ExtURIPivotSlicerCachesX15 {
+		return NameSpaceSpreadSheetX15.Value
+	}
+	return NameSpaceSpreadSheetX14.Value
+}
+
+// xlsxPivotTableSlicerCache directly maps the pivotTables element of the
+// slicerCacheDefinition extension, which associates a slicer cache with one
+// or more pivot tables and the pivot field it filters.
+type xlsxPivotTableSlicerCache struct {
+	XMLName     xml.Name                       `xml:"x14:pivotTables"`
+	PivotTables []xlsxPivotTableSlicerCacheRef `xml:"x14:pivotTable"`
+	FieldIndex  int                            `xml:"fieldIdx,attr"`
+}
+
+// xlsxPivotTableSlicerCacheRef directly maps the pivotTable element,
+// referencing a pivot cache by its associated tab ID and pivot table name.
+type xlsxPivotTableSlicerCacheRef struct {
+	TabID int    `xml:"tabId,attr"`
+	Name  string `xml:"name,attr"`
+}
+
+// decodePivotTableSlicerCache directly maps the pivotTables element of the
+// slicerCacheDefinition extension for decoding.
+type decodePivotTableSlicerCache struct {
+	XMLName     xml.Name                       `xml:"pivotTables"`
+	PivotTables []xlsxPivotTableSlicerCacheRef `xml:"pivotTable"`
+	FieldIndex  int                            `xml:"fieldIdx,attr"`
+}
+
+// slicerNameRegexp extracts the shape name written into the marshaled
+// graphicFrame of a slicer's AlternateContent choice, used to locate the
+// drawing anchor of an existing slicer when reading it back.
+var slicerNameRegexp = regexp.MustCompile(`[Nn]ame="([^"]*)"`)
+
+// GetSlicers returns all slicer settings for the given worksheet by giving
+// the worksheet name.
+func (f *File) GetSlicers(sheet string) ([]SlicerOptions, error) {
+	var slicers []SlicerOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return slicers, err
+	}
+	if ws.ExtLst == nil {
+		return slicers, err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return slicers, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISlicerListX15 {
+			continue
+		}
+		slicerList := new(decodeSlicerList)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(slicerList); err != nil && err != io.EOF {
+			return slicers, err
+		}
+		for _, slicer := range slicerList.Slicer {
+			if slicer.RID == "" {
+				continue
+			}
+			slicerXML := "xl/" + strings.TrimPrefix(f.getSheetRelationshipsTargetByID(sheet, slicer.RID), "../")
+			opts, err := f.getSlicerOptions(sheet, slicerXML)
+			if err != nil {
+				return slicers, err
+			}
+			slicers = append(slicers, opts...)
+		}
+	}
+	return slicers, nil
+}
+
+// GetSlicer returns the settings of the slicer with the given name on the
+// given worksheet.
+func (f *File) GetSlicer(sheet, name string) (*SlicerOptions, error) {
+	slicers, err := f.GetSlicers(sheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, slicer := range slicers {
+		if slicer.Name == name {
+			return &slicer, nil
+		}
+	}
+	return nil, newInvalidSlicerNameError(name)
+}
+
+// getSlicerOptions reads the xl/slicers/slicerN.xml part by the given
+// worksheet name and slicer part path, and returns the settings of every
+// slicer it contains.
+func (f *File) getSlicerOptions(sheet, slicerXML string) ([]SlicerOptions, error) {
+	var opts []SlicerOptions
+	slicers, err := f.slicerReader(slicerXML)
+	if err != nil {
+		return opts, err
+	}
+	for _, slicer := range slicers.Slicer {
+		opt := SlicerOptions{Caption: slicer.Caption, DisplayHeader: slicer.ShowCaption, Style: slicer.Style}
+		name, table, pivotTable, pivotTables, itemDesc, err := f.getSlicerCacheSource(slicer.Cache)
+		if err != nil {
+			return opts, err
+		}
+		opt.Name, opt.ItemDesc = name, itemDesc
+		if table != "" {
+			opt.Table = table
+		} else {
+			opt.Table = pivotTable
+			opt.PivotTables = pivotTables
+		}
+		if opt.Cell, opt.Width, opt.Height, opt.Format, err = f.getSlicerAnchor(sheet, slicer.Name); err != nil {
+			return opts, err
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
+// getSlicerCacheSource cross-references the slicer cache with the given
+// name and returns the original field name, the name of the table or pivot
+// table it filters, any additional connected pivot tables as "Sheet!Name"
+// references, and its sorting order.
+func (f *File) getSlicerCacheSource(cacheName string) (name, table, pivotTable string, pivotTables []string, itemDesc bool, err error) {
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if !strings.Contains(k.(string), "xl/slicerCaches/slicerCache") {
+			return true
+		}
+		slicerCache := &xlsxSlicerCacheDefinition{}
+		if decErr := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+			Decode(slicerCache); decErr != nil && decErr != io.EOF {
+			return true
+		}
+		if slicerCache.Name != cacheName || slicerCache.ExtLst == nil {
+			return true
+		}
+		name = slicerCache.SourceName
+		ext := new(xlsxExt)
+		_ = f.xmlNewDecoder(strings.NewReader(slicerCache.ExtLst.Ext)).Decode(ext)
+		if ext.URI != ExtURISlicerCacheDefinition {
+			return false
+		}
+		tableSlicerCache := new(decodeTableSlicerCache)
+		if decErr := f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(tableSlicerCache); decErr == nil && tableSlicerCache.TableID != 0 {
+			if tbl := f.getTableByID(tableSlicerCache.TableID); tbl != nil {
+				table, itemDesc = tbl.Name, tableSlicerCache.SortOrder == "descending"
+			}
+		}
+		pivotSlicerCache := new(decodePivotTableSlicerCache)
+		if decErr := f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(pivotSlicerCache); decErr == nil && len(pivotSlicerCache.PivotTables) > 0 {
+			pivotTable = pivotSlicerCache.PivotTables[0].Name
+			for _, ref := range pivotSlicerCache.PivotTables[1:] {
+				if sheet := f.getPivotTableSheetByName(ref.Name); sheet != "" {
+					pivotTables = append(pivotTables, sheet+"!"+ref.Name)
+				}
+			}
+		}
+		return false
+	})
+	if name == "" {
+		err = newInvalidSlicerNameError(cacheName)
+	}
+	return
+}
+
+// getPivotTableSheetByName returns the name of the worksheet that hosts the
+// pivot table with the given name, searching every sheet in the workbook.
+func (f *File) getPivotTableSheetByName(name string) string {
+	for _, sheet := range f.GetSheetList() {
+		pivotTables, err := f.GetPivotTables(sheet)
+		if err != nil {
+			continue
+		}
+		for _, pivotTable := range pivotTables {
+			if pivotTable.Name == name {
+				return sheet
+			}
+		}
+	}
+	return ""
+}
+
+// getTableByID returns the table definition that matches the given internal
+// table ID across all worksheets of the workbook.
+func (f *File) getTableByID(tID int) *Table {
+	for _, sheetName := range f.GetSheetList() {
+		tables, err := f.GetTables(sheetName)
+		if err != nil {
+			continue
+		}
+		for _, tbl := range tables {
+			if tbl.tID == tID {
+				t := tbl
+				return &t
+			}
+		}
+	}
+	return nil
+}
+
+// getSlicerAnchor locates the twoCellAnchor of the drawing associated with
+// the worksheet that anchors the slicer shape with the given name, and
+// returns its cell, width, height and format settings.
+func (f *File) getSlicerAnchor(sheet, name string) (string, uint, uint, GraphicOptions, error) {
+	var (
+		cell          string
+		width, height uint
+		format        GraphicOptions
+	)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return cell, width, height, format, err
+	}
+	if ws.Drawing == nil {
+		return cell, width, height, format, newInvalidSlicerNameError(name)
+	}
+	drawingXML := "xl/" + strings.TrimPrefix(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "../")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return cell, width, height, format, err
+	}
+	for _, anchor := range wsDr.TwoCellAnchor {
+		matched := false
+		for _, ac := range anchor.AlternateContent {
+			if match := slicerNameRegexp.FindStringSubmatch(ac.Content); match != nil && match[1] == name {
+				matched = true
+				break
+			}
+		}
+		if !matched || anchor.From == nil {
+			continue
+		}
+		if cell, err = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1); err != nil {
+			return cell, width, height, format, err
+		}
+		if anchor.Sp != nil && anchor.Sp.SpPr != nil {
+			width, height = uint(anchor.Sp.SpPr.Xfrm.Ext.Cx/EMU), uint(anchor.Sp.SpPr.Xfrm.Ext.Cy/EMU)
+		}
+		if anchor.ClientData != nil {
+			format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+			format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+		}
+		return cell, width, height, format, nil
+	}
+	return cell, width, height, format, newInvalidSlicerNameError(name)
+}
+
+// DeleteSlicer provides a function to delete a slicer by giving its name.
+func (f *File) DeleteSlicer(name string) error {
+	for _, sheet := range f.GetSheetList() {
+		slicers, err := f.GetSlicers(sheet)
+		if err != nil {
+			return err
+		}
+		for _, slicer := range slicers {
+			if slicer.Name != name {
+				continue
+			}
+			return f.deleteSlicer(sheet, name)
+		}
+	}
+	return newInvalidSlicerNameError(name)
+}
+
+// deleteSlicer removes the slicer with the given name: its drawing anchor,
+// its entry in the slicer XML part, and its slicer cache XML part and the
+// defined name created for the slicer cache if no longer referenced by any
+// other slicer. When the slicer XML part holds no other slicer, the part
+// itself and its worksheet extLst entry are removed too.
+func (f *File) deleteSlicer(sheet, name string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.ExtLst == nil {
+		return newInvalidSlicerNameError(name)
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for extIdx, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISlicerListX15 {
+			continue
+		}
+		slicerList := new(decodeSlicerList)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(slicerList); err != nil && err != io.EOF {
+			return err
+		}
+		for listIdx, slicer := range slicerList.Slicer {
+			if slicer.RID == "" {
+				continue
+			}
+			slicerXML := "xl/" + strings.TrimPrefix(f.getSheetRelationshipsTargetByID(sheet, slicer.RID), "../")
+			slicers, err := f.slicerReader(slicerXML)
+			if err != nil {
+				return err
+			}
+			opts, err := f.getSlicerOptions(sheet, slicerXML)
+			if err != nil {
+				return err
+			}
+			for idx, sl := range slicers.Slicer {
+				if idx >= len(opts) || opts[idx].Name != name {
+					continue
+				}
+				if err := f.removeDrawingSlicer(sheet, sl.Name); err != nil {
+					return err
+				}
+				if len(slicers.Slicer) > 1 {
+					slicers.Slicer = append(slicers.Slicer[:idx], slicers.Slicer[idx+1:]...)
+					output, err := xml.Marshal(slicers)
+					if err != nil {
+						return err
+					}
+					f.saveFileList(slicerXML, output)
+					return f.removeSlicerCacheIfUnused(sl.Cache)
+				}
+				sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+				sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+				f.deleteRelsByID(sheetRels, slicer.RID)
+				f.deleteContentTypesPart("/" + slicerXML)
+				f.Pkg.Delete(slicerXML)
+				slicerList.Slicer = append(slicerList.Slicer[:listIdx], slicerList.Slicer[listIdx+1:]...)
+				if len(slicerList.Slicer) == 0 {
+					decodeExtLst.Ext = append(decodeExtLst.Ext[:extIdx], decodeExtLst.Ext[extIdx+1:]...)
+				} else {
+					slicerListBytes, _ := xml.Marshal(slicerList)
+					decodeExtLst.Ext[extIdx].Content = string(slicerListBytes)
+				}
+				extLstBytes, err := xml.Marshal(decodeExtLst)
+				if err != nil {
+					return err
+				}
+				if len(decodeExtLst.Ext) == 0 {
+					ws.ExtLst = nil
+				} else {
+					ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+				}
+				// The slicer itself has already been removed from the
+				// worksheet above, so removeSlicerCacheIfUnused now sees
+				// an accurate picture of which slicers still reference the
+				// cache.
+				return f.removeSlicerCacheIfUnused(sl.Cache)
+			}
+		}
+	}
+	return newInvalidSlicerNameError(name)
+}
+
+// removeSlicerCacheIfUnused deletes the slicer cache XML part and its
+// defined name when no other slicer in the workbook references it anymore.
+func (f *File) removeSlicerCacheIfUnused(cacheName string) error {
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		if ws.ExtLst == nil {
+			continue
+		}
+		decodeExtLst := new(decodeExtLst)
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI != ExtURISlicerListX15 {
+				continue
+			}
+			slicerList := new(decodeSlicerList)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(slicerList); err != nil && err != io.EOF {
+				return err
+			}
+			for _, slicer := range slicerList.Slicer {
+				if slicer.RID == "" {
+					continue
+				}
+				slicerXML := "xl/" + strings.TrimPrefix(f.getSheetRelationshipsTargetByID(sheet, slicer.RID), "../")
+				slicers, err := f.slicerReader(slicerXML)
+				if err != nil {
+					return err
+				}
+				for _, sl := range slicers.Slicer {
+					if sl.Cache == cacheName {
+						return nil
+					}
+				}
+			}
+		}
+	}
+	var cacheXML, definedName string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if !strings.Contains(k.(string), "xl/slicerCaches/slicerCache") {
+			return true
+		}
+		slicerCache := &xlsxSlicerCacheDefinition{}
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+			Decode(slicerCache); err != nil && err != io.EOF {
+			return true
+		}
+		if slicerCache.Name == cacheName {
+			cacheXML, definedName = k.(string), slicerCache.Name
+			return false
+		}
+		return true
+	})
+	if cacheXML == "" {
+		return nil
+	}
+	f.Pkg.Delete(cacheXML)
+	if err := f.removeWorkbookSlicerCacheRef(cacheXML); err != nil {
+		return err
+	}
+	return f.DeleteDefinedName(&DefinedName{Name: definedName})
+}
+
+// removeDrawingSlicer removes the twoCellAnchor of the drawing associated
+// with the given worksheet that anchors the slicer shape with the given
+// name.
+func (f *File) removeDrawingSlicer(sheet, name string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Drawing == nil {
+		return nil
+	}
+	drawingXML := "xl/" + strings.TrimPrefix(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "../")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	for idx, anchor := range wsDr.TwoCellAnchor {
+		for _, ac := range anchor.AlternateContent {
+			if match := slicerNameRegexp.FindStringSubmatch(ac.Content); match != nil && match[1] == name {
+				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
+				f.Drawings.Store(drawingXML, wsDr)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// ModifySlicer provides a function to update the settings of an existing
+// slicer on the given worksheet by giving the new slicer options. The Name
+// field of opts identifies the slicer to modify and cannot be changed.
+func (f *File) ModifySlicer(sheet string, opts *SlicerOptions) error {
+	opts, err := f.parseSlicerOptions(opts)
+	if err != nil {
+		return err
+	}
+	existing, err := f.GetSlicer(sheet, opts.Name)
+	if err != nil {
+		return err
+	}
+	if _, _, _, err := f.getSlicerSource(sheet, opts); err != nil {
+		return err
+	}
+	if err := f.deleteSlicer(sheet, existing.Name); err != nil {
+		return err
+	}
+	return f.AddSlicer(sheet, opts)
+}
+
+// AddSlicerConnection provides a function to connect the slicer with the
+// given name to an additional pivot table, so that a single selection
+// filters every connected pivot table. The pivotTable parameter should be
+// a fully-qualified "Sheet!Name" reference, as returned by GetPivotTables.
+func (f *File) AddSlicerConnection(slicerName, pivotTable string) error {
+	return f.setSlicerConnection(slicerName, pivotTable, true)
+}
+
+// RemoveSlicerConnection provides a function to disconnect the slicer with
+// the given name from the given pivot table. The pivotTable parameter
+// should be a fully-qualified "Sheet!Name" reference, as returned by
+// GetPivotTables.
+func (f *File) RemoveSlicerConnection(slicerName, pivotTable string) error {
+	return f.setSlicerConnection(slicerName, pivotTable, false)
+}
+
+// setSlicerConnection adds or removes the pivot table connection of the
+// slicer with the given name, by giving the pivot table's fully-qualified
+// "Sheet!Name" reference.
+func (f *File) setSlicerConnection(slicerName, pivotTable string, add bool) error {
+	sheet, name, err := splitSlicerConnectionRef(pivotTable)
+	if err != nil {
+		return err
+	}
+	target, err := f.getPivotTableByName(sheet, name)
+	if err != nil {
+		return err
+	}
+	cacheName, err := f.findSlicerCacheName(slicerName)
+	if err != nil {
+		return err
+	}
+	cacheXML, slicerCache, err := f.getSlicerCacheByName(cacheName)
+	if err != nil {
+		return err
+	}
+	if slicerCache.ExtLst == nil {
+		return newInvalidSlicerNameError(slicerName)
+	}
+	ext := new(xlsxExt)
+	if err := f.xmlNewDecoder(strings.NewReader(slicerCache.ExtLst.Ext)).Decode(ext); err != nil && err != io.EOF {
+		return err
+	}
+	if ext.URI != ExtURISlicerCacheDefinition {
+		return newInvalidSlicerNameError(slicerName)
+	}
+	pivotSlicerCache := new(decodePivotTableSlicerCache)
+	if err := f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(pivotSlicerCache); err != nil && err != io.EOF {
+		return err
+	}
+	tabID := f.getSheetID(sheet)
+	matched := -1
+	for idx, pt := range pivotSlicerCache.PivotTables {
+		if pt.Name == target.Name && pt.TabID == tabID {
+			matched = idx
+			break
+		}
+	}
+	if add {
+		if matched != -1 {
+			return nil
+		}
+		pivotSlicerCache.PivotTables = append(pivotSlicerCache.PivotTables, xlsxPivotTableSlicerCacheRef{TabID: tabID, Name: target.Name})
+		if err := f.addWorkbookPivotCacheRels(target.CacheID); err != nil {
+			return err
+		}
+	} else {
+		if matched == -1 {
+			return nil
+		}
+		pivotSlicerCache.PivotTables = append(pivotSlicerCache.PivotTables[:matched], pivotSlicerCache.PivotTables[matched+1:]...)
+	}
+	sourceBytes, err := xml.Marshal(&xlsxPivotTableSlicerCache{PivotTables: pivotSlicerCache.PivotTables, FieldIndex: pivotSlicerCache.FieldIndex})
+	if err != nil {
+		return err
+	}
+	decodeExtLst := &decodeExtLst{Ext: []*xlsxExt{{
+		xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
+		URI:   ExtURISlicerCacheDefinition, Content: string(sourceBytes),
+	}}}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	if err != nil {
+		return err
+	}
+	slicerCache.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	slicerCacheBytes, err := xml.Marshal(slicerCache)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(cacheXML, slicerCacheBytes)
+	return nil
+}
+
+// findSlicerCacheName locates the underlying slicer cache name for the
+// slicer with the given name, searching every worksheet.
+func (f *File) findSlicerCacheName(name string) (string, error) {
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return "", err
+		}
+		if ws.ExtLst == nil {
+			continue
+		}
+		decodeExtLst := new(decodeExtLst)
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return "", err
+		}
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI != ExtURISlicerListX15 {
+				continue
+			}
+			slicerList := new(decodeSlicerList)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(slicerList); err != nil && err != io.EOF {
+				return "", err
+			}
+			for _, slicer := range slicerList.Slicer {
+				if slicer.RID == "" {
+					continue
+				}
+				slicerXML := "xl/" + strings.TrimPrefix(f.getSheetRelationshipsTargetByID(sheet, slicer.RID), "../")
+				slicers, err := f.slicerReader(slicerXML)
+				if err != nil {
+					return "", err
+				}
+				opts, err := f.getSlicerOptions(sheet, slicerXML)
+				if err != nil {
+					return "", err
+				}
+				for idx, sl := range slicers.Slicer {
+					if idx < len(opts) && opts[idx].Name == name {
+						return sl.Cache, nil
+					}
+				}
+			}
+		}
+	}
+	return "", newInvalidSlicerNameError(name)
+}
+
+// getSlicerCacheByName returns the xl/slicerCaches/slicerCacheN.xml part
+// path and the decoded slicer cache definition for the given slicer cache
+// name.
+func (f *File) getSlicerCacheByName(cacheName string) (string, *xlsxSlicerCacheDefinition, error) {
+	var (
+		cacheXML    string
+		slicerCache *xlsxSlicerCacheDefinition
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if !strings.Contains(k.(string), "xl/slicerCaches/slicerCache") {
+			return true
+		}
+		sc := &xlsxSlicerCacheDefinition{}
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+			Decode(sc); err != nil && err != io.EOF {
+			return true
+		}
+		if sc.Name == cacheName {
+			cacheXML, slicerCache = k.(string), sc
+			return false
+		}
+		return true
+	})
+	if cacheXML == "" {
+		return "", nil, newInvalidSlicerNameError(cacheName)
+	}
+	return cacheXML, slicerCache, nil
+}
+
+// addWorkbookPivotCacheRels ensures the workbook has a relationship to the
+// pivot cache definition with the given cache ID, registering one in
+// workbook.xml.rels if it is not already referenced.
+func (f *File) addWorkbookPivotCacheRels(cacheID int) error {
+	target := fmt.Sprintf("/xl/pivotCache/pivotCacheDefinition%d.xml", cacheID)
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Target == target {
+				return nil
+			}
+		}
+	}
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPivotCache, target, "")
+	return nil
+}
+
+// newInvalidPivotTableConnectionError defines an error for an invalid
+// pivot table reference given to AddSlicerConnection or
+// RemoveSlicerConnection, which must be a fully-qualified "Sheet!Name"
+// reference.
+func newInvalidPivotTableConnectionError(ref string) error {
+	return fmt.Errorf("pivot table %q should be given as a qualified \"Sheet!Name\" reference", ref)
+}
+
+// deleteRelsByID removes the relationship with the given r:id from the
+// relationships part at the given path.
+func (f *File) deleteRelsByID(relsPath, rID string) {
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return
+	}
+	for idx, rel := range rels.Relationships {
+		if rel.ID == rID {
+			rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+			break
+		}
+	}
+	f.Relationships.Store(relsPath, rels)
+}
+
+// deleteRelsByTarget removes every relationship with the given target from
+// the relationships part at the given path and returns their r:id values.
+func (f *File) deleteRelsByTarget(relsPath, target string) []string {
+	var rIDs []string
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return rIDs
+	}
+	for idx := 0; idx < len(rels.Relationships); {
+		if rels.Relationships[idx].Target == target {
+			rIDs = append(rIDs, rels.Relationships[idx].ID)
+			rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+			continue
+		}
+		idx++
+	}
+	f.Relationships.Store(relsPath, rels)
+	return rIDs
+}
+
+// deleteContentTypesPart removes the content-type override registered for
+// the given part name, if any.
+func (f *File) deleteContentTypesPart(partName string) {
+	content := f.contentTypesReader()
+	for idx, overRide := range content.Overrides {
+		if overRide.PartName == partName {
+			content.Overrides = append(content.Overrides[:idx], content.Overrides[idx+1:]...)
+			return
+		}
+	}
+}
+
+// slicerCacheRefRegexp matches a single x14:slicerCache reference with the
+// given r:id inside the raw content of a workbook-level x14/x15:slicerCaches
+// extension.
+func slicerCacheRefRegexp(rID string) *regexp.Regexp {
+	return regexp.MustCompile(`<x14:slicerCache[^>]*r:id="` + regexp.QuoteMeta(rID) + `"[^>]*(?:/>|>.*?</x14:slicerCache>)`)
+}
+
+// removeWorkbookSlicerCacheRef removes the workbook-level relationship, the
+// content-type override, and the x14/x15:slicerCaches extLst entry that
+// reference the slicer cache part with the given name, so that deleting a
+// slicer cache does not leave the workbook needing repair on reopen.
+func (f *File) removeWorkbookSlicerCacheRef(cacheXML string) error {
+	target := "/" + cacheXML
+	f.deleteContentTypesPart(target)
+	rIDs := f.deleteRelsByTarget(f.getWorkbookRelsPath(), target)
+	if len(rIDs) == 0 {
+		return nil
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + wb.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for _, rID := range rIDs {
+		ref := slicerCacheRefRegexp(rID)
+		for extIdx := 0; extIdx < len(decodeExtLst.Ext); extIdx++ {
+			ext := decodeExtLst.Ext[extIdx]
+			if ext.URI != ExtURISlicerCachesX15 && ext.URI != ExtURIPivotSlicerCachesX15 {
+				continue
+			}
+			decodeSlicerCaches := new(decodeX15SlicerCaches)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeSlicerCaches); err != nil && err != io.EOF {
+				return err
+			}
+			if !ref.MatchString(decodeSlicerCaches.Content) {
+				continue
+			}
+			if content := ref.ReplaceAllString(decodeSlicerCaches.Content, ""); content == "" {
+				decodeExtLst.Ext = append(decodeExtLst.Ext[:extIdx], decodeExtLst.Ext[extIdx+1:]...)
+			} else {
+				slicerCachesBytes, marshalErr := xml.Marshal(&xlsxX15SlicerCaches{Content: content, XMLNS: slicerCachesNameSpace(ext.URI)})
+				if marshalErr != nil {
+					return marshalErr
+				}
+				decodeExtLst.Ext[extIdx].Content = string(slicerCachesBytes)
+			}
+			break
+		}
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	if err != nil {
+		return err
+	}
+	if len(decodeExtLst.Ext) == 0 {
+		wb.ExtLst = nil
+	} else {
+		wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	}
+	return nil
+}
+
+// newInvalidSlicerStyleError defines an error for an invalid slicer style
+// name, which is neither one of the built-in styles nor a style registered
+// with AddSlicerStyle.
+func newInvalidSlicerStyleError(name string) error {
+	return fmt.Errorf("slicer style %q does not exist", name)
+}
+
+// hasSlicerStyle returns whether a custom slicer style with the given name
+// has already been registered with AddSlicerStyle.
+func (f *File) hasSlicerStyle(name string) (bool, error) {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return false, err
+	}
+	if styleSheet.TableStyles == nil {
+		return false, nil
+	}
+	for _, tableStyle := range styleSheet.TableStyles.TableStyle {
+		if tableStyle.Name == name && tableStyle.Type == slicerStyleType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// slicerStyleType is the tableStyle type attribute value used to mark a
+// table style definition as a slicer style, as opposed to a regular table
+// style.
+const slicerStyleType = "slicerStyle"
+
+// slicerStyleElements associates each of the nine slicer style elements
+// with its OOXML tableStyleElement type name and the corresponding
+// SlicerStyle field accessor.
+var slicerStyleElements = []struct {
+	typeName string
+	get      func(*SlicerStyle) *Style
+}{
+	{"wholeTable", func(s *SlicerStyle) *Style { return s.WholeTable }},
+	{"header", func(s *SlicerStyle) *Style { return s.Header }},
+	{"selectedItemWithData", func(s *SlicerStyle) *Style { return s.SelectedItemWithData }},
+	{"selectedItemWithNoData", func(s *SlicerStyle) *Style { return s.SelectedItemWithNoData }},
+	{"unselectedItemWithData", func(s *SlicerStyle) *Style { return s.UnselectedItemWithData }},
+	{"unselectedItemWithNoData", func(s *SlicerStyle) *Style { return s.UnselectedItemWithNoData }},
+	{"hoveredSelectedItemWithData", func(s *SlicerStyle) *Style { return s.HoveredSelectedItemWithData }},
+	{"hoveredSelectedItemWithNoData", func(s *SlicerStyle) *Style { return s.HoveredSelectedItemWithNoData }},
+	{"hoveredUnselectedItemWithData", func(s *SlicerStyle) *Style { return s.HoveredUnselectedItemWithData }},
+}
+
+// SlicerStyle directly maps the formatting of a custom slicer style,
+// covering the nine distinct slicer style elements. Each element is
+// optional; elements left nil keep Excel's default formatting.
+type SlicerStyle struct {
+	WholeTable                    *Style
+	Header                        *Style
+	SelectedItemWithData          *Style
+	SelectedItemWithNoData        *Style
+	UnselectedItemWithData        *Style
+	UnselectedItemWithNoData      *Style
+	HoveredSelectedItemWithData   *Style
+	HoveredSelectedItemWithNoData *Style
+	HoveredUnselectedItemWithData *Style
+}
+
+// xlsxTableStyle directly maps a tableStyle element of the tableStyles
+// collection in xl/styles.xml. Besides regular table styles, this is also
+// used to persist custom slicer styles, identified by Type being
+// slicerStyleType.
+type xlsxTableStyle struct {
+	Name              string                  `xml:"name,attr"`
+	Type              string                  `xml:"type,attr,omitempty"`
+	Pivot             *bool                   `xml:"pivot,attr"`
+	Table             *bool                   `xml:"table,attr"`
+	Count             int                     `xml:"count,attr,omitempty"`
+	TableStyleElement []xlsxTableStyleElement `xml:"tableStyleElement"`
+}
+
+// xlsxTableStyleElement directly maps a tableStyleElement child of a
+// tableStyle, associating one of the nine slicer style elements with a
+// differential formatting record (dxf) by index.
+type xlsxTableStyleElement struct {
+	Type  string `xml:"type,attr"`
+	Size  int    `xml:"size,attr,omitempty"`
+	DxfID int    `xml:"dxfId,attr"`
+}
+
+// AddSlicerStyle provides a function to create a custom slicer style by
+// giving the style name and element settings. The registered name can
+// afterwards be assigned to the Style field of SlicerOptions, the same way
+// as one of Excel's built-in slicer style names.
+//
+// For example, create a custom slicer style with a yellow selected item:
+//
+//	err := f.AddSlicerStyle("SlicerStyleCustom1", &excelize.SlicerStyle{
+//	    SelectedItemWithData: &excelize.Style{
+//	        Fill: excelize.Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+//	    },
+//	})
+func (f *File) AddSlicerStyle(name string, style *SlicerStyle) error {
+	if name == "" || style == nil {
+		return ErrParameterRequired
+	}
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	tableStyle := xlsxTableStyle{Name: name, Type: slicerStyleType, Pivot: boolPtr(false), Table: boolPtr(false)}
+	for _, elem := range slicerStyleElements {
+		elemStyle := elem.get(style)
+		if elemStyle == nil {
+			continue
+		}
+		dxfID, err := f.addSlicerStyleDxf(elemStyle)
+		if err != nil {
+			return err
+		}
+		tableStyle.TableStyleElement = append(tableStyle.TableStyleElement, xlsxTableStyleElement{Type: elem.typeName, DxfID: dxfID})
+	}
+	if styleSheet.TableStyles == nil {
+		styleSheet.TableStyles = &xlsxTableStyles{}
+	}
+	styleSheet.TableStyles.TableStyle = append(styleSheet.TableStyles.TableStyle, tableStyle)
+	styleSheet.TableStyles.Count = len(styleSheet.TableStyles.TableStyle)
+	return nil
+}
+
+// addSlicerStyleDxf appends a differential formatting record built from the
+// given style to the workbook's dxfs list and returns its index.
+func (f *File) addSlicerStyleDxf(style *Style) (int, error) {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return 0, err
+	}
+	dxf := xlsxDxf{}
+	if style.Font != nil {
+		dxf.Font = newFont(style.Font)
+	}
+	if style.Fill.Type != "" {
+		dxf.Fill = newFills(style.Fill, false)
+	}
+	if len(style.Border) > 0 {
+		dxf.Border = newBorders(style.Border)
+	}
+	if styleSheet.Dxfs == nil {
+		styleSheet.Dxfs = &xlsxDxfs{}
+	}
+	styleSheet.Dxfs.Dxf = append(styleSheet.Dxfs.Dxf, &dxf)
+	styleSheet.Dxfs.Count = len(styleSheet.Dxfs.Dxf)
+	return len(styleSheet.Dxfs.Dxf) - 1, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package excelize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSlicer(t *testing.T) {
+	f := NewFile()
+	require.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Column1", "Column2"}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A", 1}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"B", 2}))
+	require.NoError(t, f.AddTable("Sheet1", &TableOptions{Range: "A1:B3", Name: "Table1"}))
+
+	require.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:  "Column1",
+		Table: "Table1",
+		Cell:  "D2",
+		Style: SlicerStyleLight1,
+	}))
+
+	slicers, err := f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, slicers, 1)
+	assert.Equal(t, "Column1", slicers[0].Name)
+	assert.Equal(t, "Table1", slicers[0].Table)
+	assert.Equal(t, SlicerStyleLight1, slicers[0].Style)
+
+	slicer, err := f.GetSlicer("Sheet1", "Column1")
+	require.NoError(t, err)
+	assert.Equal(t, "Column1", slicer.Name)
+
+	// Modifying a slicer with an unresolvable table should leave the
+	// original slicer intact.
+	assert.Error(t, f.ModifySlicer("Sheet1", &SlicerOptions{
+		Name:  "Column1",
+		Table: "NoExistTable",
+		Cell:  "D2",
+	}))
+	slicers, err = f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, slicers, 1)
+
+	require.NoError(t, f.ModifySlicer("Sheet1", &SlicerOptions{
+		Name:  "Column1",
+		Table: "Table1",
+		Cell:  "D2",
+		Style: SlicerStyleLight2,
+	}))
+	slicers, err = f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, slicers, 1)
+	assert.Equal(t, SlicerStyleLight2, slicers[0].Style)
+
+	require.NoError(t, f.DeleteSlicer("Column1"))
+	slicers, err = f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	assert.Len(t, slicers, 0)
+
+	// Deleting a slicer that has already been removed returns an error.
+	assert.Error(t, f.DeleteSlicer("Column1"))
+}
+
+func TestDeleteSlicer(t *testing.T) {
+	f := NewFile()
+	require.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Column1"}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A"}))
+	require.NoError(t, f.AddTable("Sheet1", &TableOptions{Range: "A1:A2", Name: "Table2"}))
+	require.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{Name: "Column1", Table: "Table2", Cell: "C2"}))
+
+	assert.NoError(t, f.DeleteSlicer("Column1"))
+
+	var found bool
+	f.Pkg.Range(func(k, _ interface{}) bool {
+		if k.(string) == "xl/slicerCaches/slicerCache1.xml" {
+			found = true
+			return false
+		}
+		return true
+	})
+	assert.False(t, found, "slicer cache part should have been removed")
+
+	wb, err := f.workbookReader()
+	require.NoError(t, err)
+	if wb.ExtLst != nil {
+		assert.NotContains(t, wb.ExtLst.Ext, "slicerCache1.xml", "workbook extLst should not reference the deleted slicer cache")
+	}
+
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	require.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, "/xl/slicerCaches/slicerCache1.xml", rel.Target, "workbook rels should not reference the deleted slicer cache")
+	}
+
+	content := f.contentTypesReader()
+	for _, overRide := range content.Overrides {
+		assert.NotEqual(t, "/xl/slicerCaches/slicerCache1.xml", overRide.PartName, "content types should not reference the deleted slicer cache")
+		assert.NotEqual(t, "/xl/slicers/slicer1.xml", overRide.PartName, "content types should not reference the deleted slicer")
+	}
+}
+
+func TestAddSlicerPivotTable(t *testing.T) {
+	f := NewFile()
+	require.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Region", "Value"}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"East", 1}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"West", 2}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{"East", 3}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"West", 4}))
+
+	require.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:B5",
+		PivotTableRange: "Sheet1!D1:E5",
+		Name:            "PivotTable1",
+		RowFields:       []PivotTableField{{Data: "Region"}},
+		DataFields:      []PivotTableField{{Data: "Value"}},
+	}))
+	require.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:B5",
+		PivotTableRange: "Sheet1!G1:H5",
+		Name:            "PivotTable2",
+		RowFields:       []PivotTableField{{Data: "Region"}},
+		DataFields:      []PivotTableField{{Data: "Value"}},
+	}))
+
+	require.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name:  "Value",
+		Table: "PivotTable1",
+		Cell:  "J2",
+	}))
+
+	slicers, err := f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, slicers, 1)
+	assert.Equal(t, "Value", slicers[0].Name)
+	assert.Equal(t, "PivotTable1", slicers[0].Table)
+	assert.Empty(t, slicers[0].PivotTables)
+
+	require.NoError(t, f.AddSlicerConnection("Value", "Sheet1!PivotTable2"))
+	slicers, err = f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, slicers, 1)
+	assert.Equal(t, []string{"Sheet1!PivotTable2"}, slicers[0].PivotTables)
+
+	require.NoError(t, f.RemoveSlicerConnection("Value", "Sheet1!PivotTable2"))
+	slicers, err = f.GetSlicers("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, slicers, 1)
+	assert.Empty(t, slicers[0].PivotTables)
+}
@@ -0,0 +1,690 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ExtURISparklineGroups is the extension URI used to register the
+// sparkline groups of a worksheet under its extLst.
+const ExtURISparklineGroups = "{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"
+
+// init registers ExtURISparklineGroups with extensionURIPriority so that
+// addSheetSparklineGroup orders it against the other worksheet extLst
+// extensions instead of falling into inStrSlice's "not found" case.
+func init() {
+	if inStrSlice(extensionURIPriority, ExtURISparklineGroups, false) == -1 {
+		extensionURIPriority = append(extensionURIPriority, ExtURISparklineGroups)
+	}
+}
+
+// NameSpaceSpreadSheetXM is the namespace used for the formula and range
+// reference elements (xm:f, xm:sqref) referenced from x14 extensions, such
+// as sparkline groups.
+var NameSpaceSpreadSheetXM = xml.Attr{Name: xml.Name{Local: "xmlns:xm"}, Value: "http://schemas.microsoft.com/office/excel/2006/main"}
+
+// Built-in sparkline types that can be assigned to SparklineOptions.Type.
+const (
+	SparklineTypeLine    = "line"
+	SparklineTypeColumn  = "column"
+	SparklineTypeWinLoss = "win_loss"
+)
+
+// Built-in axis scaling modes that can be assigned to SparklineOptions.MinAxisType
+// and SparklineOptions.MaxAxisType.
+const (
+	SparklineAxisTypeGroup      = "group"
+	SparklineAxisTypeIndividual = "individual"
+	SparklineAxisTypeCustom     = "custom"
+)
+
+// Built-in values that can be assigned to SparklineOptions.DisplayEmptyCellsAs.
+const (
+	SparklineEmptyCellsAsGap     = "gap"
+	SparklineEmptyCellsAsZero    = "zero"
+	SparklineEmptyCellsAsConnect = "connect"
+)
+
+// SparklineOptions represents the settings of a sparkline group.
+//
+// Location specifies the cell coordinates that each sparkline should be
+// rendered in, this setting is required.
+//
+// Range specifies the data range that each sparkline should be created
+// from, for example "Sheet1!A1:J1". Range and Location are paired up by
+// index and must have the same length, this setting is required.
+//
+// Type specifies the type of the sparklines in the group, it should be
+// SparklineTypeLine, SparklineTypeColumn, or SparklineTypeWinLoss, this
+// setting is optional, the default type is SparklineTypeLine.
+//
+// Style specifies one of Excel's 36 built-in sparkline styles (0-35), this
+// setting is optional, and the default setting is 0. Custom colors set
+// below take precedence over the style.
+//
+// SeriesColor, NegativeColor, MarkersColor, FirstColor, LastColor,
+// HighColor and LowColor override the colors of the built-in style with
+// the given RGB hex color code, for example "FF0000", these settings are
+// optional.
+//
+// DateAxisRange specifies the cell range containing date values used to
+// space the data points along a date axis, for example "Sheet1!A1:E1",
+// this setting is optional.
+//
+// MinAxisType and MaxAxisType specify the vertical axis scaling of the
+// group, the options are SparklineAxisTypeGroup, SparklineAxisTypeIndividual,
+// and SparklineAxisTypeCustom, these settings are optional, and the default
+// setting is SparklineAxisTypeIndividual (or SparklineAxisTypeGroup when
+// Group is true).
+//
+// CustomMin and CustomMax specify the manual axis bounds used when
+// MinAxisType or MaxAxisType is set to SparklineAxisTypeCustom, these
+// settings are optional.
+//
+// DisplayEmptyCellsAs specifies how empty cells are plotted, the options
+// are SparklineEmptyCellsAsGap, SparklineEmptyCellsAsZero, and
+// SparklineEmptyCellsAsConnect, this setting is optional, and the default
+// setting is SparklineEmptyCellsAsGap.
+//
+// ShowMarkers specifies whether to display markers on line sparklines,
+// this setting is optional.
+//
+// RightToLeft specifies whether the sparklines are plotted right-to-left,
+// this setting is optional.
+//
+// Group specifies whether the sparklines added by this call share a common
+// vertical axis scale, this setting is optional.
+type SparklineOptions struct {
+	Location            []string
+	Range               []string
+	Type                string
+	Style               int
+	SeriesColor         string
+	NegativeColor       string
+	MarkersColor        string
+	FirstColor          string
+	LastColor           string
+	HighColor           string
+	LowColor            string
+	DateAxisRange       string
+	MinAxisType         string
+	MaxAxisType         string
+	CustomMin           float64
+	CustomMax           float64
+	DisplayEmptyCellsAs string
+	ShowMarkers         bool
+	RightToLeft         bool
+	Group               bool
+}
+
+// xlsxX14SparklineGroupsExt directly maps the x14:sparklineGroups element
+// written into a worksheet's extLst, holding the raw, already marshaled
+// x14:sparklineGroup children.
+type xlsxX14SparklineGroupsExt struct {
+	XMLName xml.Name `xml:"x14:sparklineGroups"`
+	XMLNSXM string   `xml:"xmlns:xm,attr"`
+	Content string   `xml:",innerxml"`
+}
+
+// decodeX14SparklineGroupsExt directly maps the x14:sparklineGroups element
+// for decoding its raw, unparsed x14:sparklineGroup children.
+type decodeX14SparklineGroupsExt struct {
+	XMLName xml.Name `xml:"sparklineGroups"`
+	Content string   `xml:",innerxml"`
+}
+
+// decodeWorksheetSparklineGroups directly maps the x14:sparklineGroups
+// element for structured decoding.
+type decodeWorksheetSparklineGroups struct {
+	XMLName        xml.Name                        `xml:"sparklineGroups"`
+	SparklineGroup []decodeWorksheetSparklineGroup `xml:"sparklineGroup"`
+}
+
+// decodeWorksheetSparklineGroup directly maps an x14:sparklineGroup element
+// for structured decoding.
+type decodeWorksheetSparklineGroup struct {
+	ManualMax           float64               `xml:"manualMax,attr"`
+	ManualMin           float64               `xml:"manualMin,attr"`
+	Type                string                `xml:"type,attr"`
+	F                   string                `xml:"f"`
+	DateAxis            bool                  `xml:"dateAxis,attr"`
+	DisplayEmptyCellsAs string                `xml:"displayEmptyCellsAs,attr"`
+	Markers             bool                  `xml:"markers,attr"`
+	High                bool                  `xml:"high,attr"`
+	Low                 bool                  `xml:"low,attr"`
+	First               bool                  `xml:"first,attr"`
+	Last                bool                  `xml:"last,attr"`
+	Negative            bool                  `xml:"negative,attr"`
+	DisplayXAxis        bool                  `xml:"displayXAxis,attr"`
+	DisplayHidden       bool                  `xml:"displayHidden,attr"`
+	MinAxisType         string                `xml:"minAxisType,attr"`
+	MaxAxisType         string                `xml:"maxAxisType,attr"`
+	RightToLeft         bool                  `xml:"rightToLeft,attr"`
+	ColorSeries         *decodeSparklineColor `xml:"colorSeries"`
+	ColorNegative       *decodeSparklineColor `xml:"colorNegative"`
+	ColorAxis           *decodeSparklineColor `xml:"colorAxis"`
+	ColorMarkers        *decodeSparklineColor `xml:"colorMarkers"`
+	ColorFirst          *decodeSparklineColor `xml:"colorFirst"`
+	ColorLast           *decodeSparklineColor `xml:"colorLast"`
+	ColorHigh           *decodeSparklineColor `xml:"colorHigh"`
+	ColorLow            *decodeSparklineColor `xml:"colorLow"`
+	Sparklines          struct {
+		Sparkline []decodeSparkline `xml:"sparkline"`
+	} `xml:"sparklines"`
+}
+
+// decodeSparklineColor directly maps a colorXxx child element of an
+// x14:sparklineGroup for decoding.
+type decodeSparklineColor struct {
+	Theme *int   `xml:"theme,attr"`
+	Tint  string `xml:"tint,attr"`
+	RGB   string `xml:"rgb,attr"`
+}
+
+// decodeSparkline directly maps an x14:sparkline element for decoding.
+type decodeSparkline struct {
+	F     string `xml:"f"`
+	Sqref string `xml:"sqref"`
+}
+
+// xlsxX14SparklineGroup directly maps the x14:sparklineGroup element,
+// describing the shared type, color and axis settings of one or more
+// sparklines.
+type xlsxX14SparklineGroup struct {
+	XMLName             xml.Name               `xml:"x14:sparklineGroup"`
+	ManualMax           float64                `xml:"manualMax,attr,omitempty"`
+	ManualMin           float64                `xml:"manualMin,attr,omitempty"`
+	Type                string                 `xml:"type,attr,omitempty"`
+	F                   string                 `xml:"xm:f,omitempty"`
+	DateAxis            bool                   `xml:"dateAxis,attr,omitempty"`
+	DisplayEmptyCellsAs string                 `xml:"displayEmptyCellsAs,attr,omitempty"`
+	Markers             bool                   `xml:"markers,attr,omitempty"`
+	High                bool                   `xml:"high,attr,omitempty"`
+	Low                 bool                   `xml:"low,attr,omitempty"`
+	First               bool                   `xml:"first,attr,omitempty"`
+	Last                bool                   `xml:"last,attr,omitempty"`
+	Negative            bool                   `xml:"negative,attr,omitempty"`
+	DisplayXAxis        bool                   `xml:"displayXAxis,attr,omitempty"`
+	DisplayHidden       bool                   `xml:"displayHidden,attr,omitempty"`
+	MinAxisType         string                 `xml:"minAxisType,attr,omitempty"`
+	MaxAxisType         string                 `xml:"maxAxisType,attr,omitempty"`
+	RightToLeft         bool                   `xml:"rightToLeft,attr,omitempty"`
+	ColorSeries         *xlsxX14SparklineColor `xml:"x14:colorSeries"`
+	ColorNegative       *xlsxX14SparklineColor `xml:"x14:colorNegative"`
+	ColorAxis           *xlsxX14SparklineColor `xml:"x14:colorAxis"`
+	ColorMarkers        *xlsxX14SparklineColor `xml:"x14:colorMarkers"`
+	ColorFirst          *xlsxX14SparklineColor `xml:"x14:colorFirst"`
+	ColorLast           *xlsxX14SparklineColor `xml:"x14:colorLast"`
+	ColorHigh           *xlsxX14SparklineColor `xml:"x14:colorHigh"`
+	ColorLow            *xlsxX14SparklineColor `xml:"x14:colorLow"`
+	Sparklines          xlsxX14Sparklines      `xml:"x14:sparklines"`
+}
+
+// xlsxX14SparklineColor directly maps a colorXxx child element of an
+// x14:sparklineGroup.
+type xlsxX14SparklineColor struct {
+	Theme *int   `xml:"theme,attr,omitempty"`
+	Tint  string `xml:"tint,attr,omitempty"`
+	RGB   string `xml:"rgb,attr,omitempty"`
+}
+
+// xlsxX14Sparklines directly maps the x14:sparklines element, the
+// collection of individual sparklines belonging to a sparkline group.
+type xlsxX14Sparklines struct {
+	Sparkline []xlsxX14Sparkline `xml:"x14:sparkline"`
+}
+
+// xlsxX14Sparkline directly maps the x14:sparkline element, associating the
+// data range with the cell the sparkline is rendered in.
+type xlsxX14Sparkline struct {
+	F     string `xml:"xm:f"`
+	Sqref string `xml:"xm:sqref"`
+}
+
+// AddSparklineGroup provides a function to add one or more sparklines
+// sharing the same type, style and axis settings to a worksheet, by giving
+// the worksheet name and sparkline group options.
+//
+// For example, add line sparklines summarizing Sheet1!A1:E1, Sheet1!A2:E2
+// and Sheet1!A3:E3 into F1, F2 and F3:
+//
+//	err := f.AddSparklineGroup("Sheet1", &excelize.SparklineOptions{
+//	    Location: []string{"F1", "F2", "F3"},
+//	    Range:    []string{"Sheet1!A1:E1", "Sheet1!A2:E2", "Sheet1!A3:E3"},
+//	    Type:     excelize.SparklineTypeLine,
+//	})
+func (f *File) AddSparklineGroup(sheet string, opts *SparklineOptions) error {
+	opts, err := parseSparklineOptions(opts)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	return f.addSheetSparklineGroup(ws, newSparklineGroup(opts))
+}
+
+// parseSparklineOptions provides a function to parse the settings of the
+// sparkline group with default value.
+func parseSparklineOptions(opts *SparklineOptions) (*SparklineOptions, error) {
+	if opts == nil {
+		return nil, ErrParameterRequired
+	}
+	if len(opts.Location) == 0 || len(opts.Location) != len(opts.Range) {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Type == "" {
+		opts.Type = SparklineTypeLine
+	}
+	if opts.Type != SparklineTypeLine && opts.Type != SparklineTypeColumn && opts.Type != SparklineTypeWinLoss {
+		return nil, newInvalidSparklineTypeError(opts.Type)
+	}
+	if opts.Style < 0 || opts.Style > 35 {
+		return nil, newInvalidSparklineStyleError(opts.Style)
+	}
+	if opts.MinAxisType == "" {
+		opts.MinAxisType = SparklineAxisTypeIndividual
+		if opts.Group {
+			opts.MinAxisType = SparklineAxisTypeGroup
+		}
+	}
+	if opts.MinAxisType != SparklineAxisTypeGroup && opts.MinAxisType != SparklineAxisTypeIndividual && opts.MinAxisType != SparklineAxisTypeCustom {
+		return nil, newInvalidSparklineAxisTypeError(opts.MinAxisType)
+	}
+	if opts.MaxAxisType == "" {
+		opts.MaxAxisType = SparklineAxisTypeIndividual
+		if opts.Group {
+			opts.MaxAxisType = SparklineAxisTypeGroup
+		}
+	}
+	if opts.MaxAxisType != SparklineAxisTypeGroup && opts.MaxAxisType != SparklineAxisTypeIndividual && opts.MaxAxisType != SparklineAxisTypeCustom {
+		return nil, newInvalidSparklineAxisTypeError(opts.MaxAxisType)
+	}
+	if opts.DisplayEmptyCellsAs == "" {
+		opts.DisplayEmptyCellsAs = SparklineEmptyCellsAsGap
+	}
+	if opts.DisplayEmptyCellsAs != SparklineEmptyCellsAsGap && opts.DisplayEmptyCellsAs != SparklineEmptyCellsAsZero && opts.DisplayEmptyCellsAs != SparklineEmptyCellsAsConnect {
+		return nil, newInvalidSparklineEmptyCellsAsError(opts.DisplayEmptyCellsAs)
+	}
+	return opts, nil
+}
+
+// sparklineColor returns the xlsxX14SparklineColor for the given RGB hex
+// color code, or nil when no color was given.
+func sparklineColor(color string) *xlsxX14SparklineColor {
+	if color == "" {
+		return nil
+	}
+	return &xlsxX14SparklineColor{RGB: "FF" + strings.ToUpper(strings.TrimPrefix(color, "#"))}
+}
+
+// sparklineStyleColors resolves one of Excel's 36 built-in sparkline
+// styles into its series, negative, axis, markers, first, last, high and
+// low colors, cycling through the six accent theme colors (theme index
+// 4-9) every six consecutive style indices.
+func sparklineStyleColors(style int) (series, negative, axis, markers, first, last, high, low *xlsxX14SparklineColor) {
+	theme, negTheme := 4+style%6, 4+(style+3)%6
+	return &xlsxX14SparklineColor{Theme: intPtr(theme)},
+		&xlsxX14SparklineColor{Theme: intPtr(negTheme)},
+		&xlsxX14SparklineColor{Theme: intPtr(1)},
+		&xlsxX14SparklineColor{Theme: intPtr(theme), Tint: "-0.499984740745262"},
+		&xlsxX14SparklineColor{Theme: intPtr(theme), Tint: "-0.249977111117893"},
+		&xlsxX14SparklineColor{Theme: intPtr(theme), Tint: "-0.249977111117893"},
+		&xlsxX14SparklineColor{Theme: intPtr(theme)},
+		&xlsxX14SparklineColor{Theme: intPtr(theme)}
+}
+
+// newSparklineGroup builds the x14:sparklineGroup element for the given
+// sparkline group options.
+func newSparklineGroup(opts *SparklineOptions) *xlsxX14SparklineGroup {
+	series, negative, axis, markers, first, last, high, low := sparklineStyleColors(opts.Style)
+	if c := sparklineColor(opts.SeriesColor); c != nil {
+		series = c
+	}
+	if c := sparklineColor(opts.NegativeColor); c != nil {
+		negative = c
+	}
+	if c := sparklineColor(opts.MarkersColor); c != nil {
+		markers = c
+	}
+	if c := sparklineColor(opts.FirstColor); c != nil {
+		first = c
+	}
+	if c := sparklineColor(opts.LastColor); c != nil {
+		last = c
+	}
+	if c := sparklineColor(opts.HighColor); c != nil {
+		high = c
+	}
+	if c := sparklineColor(opts.LowColor); c != nil {
+		low = c
+	}
+	group := &xlsxX14SparklineGroup{
+		Type:                opts.Type,
+		F:                   opts.DateAxisRange,
+		DateAxis:            opts.DateAxisRange != "",
+		DisplayEmptyCellsAs: sparklineDisplayEmptyCellsAsAttr(opts.DisplayEmptyCellsAs),
+		Markers:             opts.ShowMarkers,
+		RightToLeft:         opts.RightToLeft,
+		MinAxisType:         opts.MinAxisType,
+		MaxAxisType:         opts.MaxAxisType,
+		ColorSeries:         series,
+		ColorNegative:       negative,
+		ColorAxis:           axis,
+		ColorMarkers:        markers,
+		ColorFirst:          first,
+		ColorLast:           last,
+		ColorHigh:           high,
+		ColorLow:            low,
+		First:               first != nil,
+		Last:                last != nil,
+		High:                high != nil,
+		Low:                 low != nil,
+		Negative:            negative != nil,
+	}
+	if opts.MinAxisType == SparklineAxisTypeCustom {
+		group.ManualMin = opts.CustomMin
+	}
+	if opts.MaxAxisType == SparklineAxisTypeCustom {
+		group.ManualMax = opts.CustomMax
+	}
+	for idx, location := range opts.Location {
+		group.Sparklines.Sparkline = append(group.Sparklines.Sparkline, xlsxX14Sparkline{F: opts.Range[idx], Sqref: location})
+	}
+	return group
+}
+
+// sparklineDisplayEmptyCellsAsAttr maps SparklineOptions.DisplayEmptyCellsAs
+// to the displayEmptyCellsAs attribute value expected by Excel, translating
+// the descriptive SparklineEmptyCellsAsConnect setting to the OOXML "span"
+// value.
+func sparklineDisplayEmptyCellsAsAttr(displayEmptyCellsAs string) string {
+	if displayEmptyCellsAs == SparklineEmptyCellsAsConnect {
+		return "span"
+	}
+	return displayEmptyCellsAs
+}
+
+// sparklineDisplayEmptyCellsAsOption is the inverse of
+// sparklineDisplayEmptyCellsAsAttr, used when reading a sparkline group
+// back.
+func sparklineDisplayEmptyCellsAsOption(displayEmptyCellsAs string) string {
+	if displayEmptyCellsAs == "span" {
+		return SparklineEmptyCellsAsConnect
+	}
+	return displayEmptyCellsAs
+}
+
+// addSheetSparklineGroup appends a new sparkline group to the worksheet's
+// extLst under the x14:sparklineGroups extension, creating the extension
+// if it does not exist yet.
+func (f *File) addSheetSparklineGroup(ws *xlsxWorksheet, group *xlsxX14SparklineGroup) error {
+	decodeExtLst := new(decodeExtLst)
+	if ws.ExtLst != nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	groupBytes, err := xml.Marshal(group)
+	if err != nil {
+		return err
+	}
+	var found bool
+	for idx, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISparklineGroups {
+			continue
+		}
+		existing := new(decodeX14SparklineGroupsExt)
+		_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(existing)
+		groupsBytes, err := xml.Marshal(xlsxX14SparklineGroupsExt{XMLNSXM: NameSpaceSpreadSheetXM.Value, Content: existing.Content + string(groupBytes)})
+		if err != nil {
+			return err
+		}
+		decodeExtLst.Ext[idx].Content = string(groupsBytes)
+		found = true
+		break
+	}
+	if !found {
+		groupsBytes, err := xml.Marshal(xlsxX14SparklineGroupsExt{XMLNSXM: NameSpaceSpreadSheetXM.Value, Content: string(groupBytes)})
+		if err != nil {
+			return err
+		}
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
+			xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX14.Name.Local}, Value: NameSpaceSpreadSheetX14.Value}},
+			URI:   ExtURISparklineGroups, Content: string(groupsBytes),
+		})
+	}
+	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(extensionURIPriority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(extensionURIPriority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	if err != nil {
+		return err
+	}
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return nil
+}
+
+// GetSparklineGroups returns the settings of every sparkline group on the
+// given worksheet. The Style field cannot be recovered once written, and
+// is always returned as 0; colors given as custom RGB values are returned
+// as hex color codes, and colors that come from the built-in style are
+// returned as empty strings.
+func (f *File) GetSparklineGroups(sheet string) ([]SparklineOptions, error) {
+	var groups []SparklineOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return groups, err
+	}
+	if ws.ExtLst == nil {
+		return groups, nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return groups, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISparklineGroups {
+			continue
+		}
+		decoded := new(decodeWorksheetSparklineGroups)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decoded); err != nil && err != io.EOF {
+			return groups, err
+		}
+		for _, group := range decoded.SparklineGroup {
+			groups = append(groups, sparklineOptionsFromDecoded(group))
+		}
+	}
+	return groups, nil
+}
+
+// sparklineOptionsFromDecoded converts a decoded x14:sparklineGroup back
+// into SparklineOptions.
+func sparklineOptionsFromDecoded(group decodeWorksheetSparklineGroup) SparklineOptions {
+	opts := SparklineOptions{
+		Type:                group.Type,
+		SeriesColor:         sparklineColorToHex(group.ColorSeries),
+		NegativeColor:       sparklineColorToHex(group.ColorNegative),
+		MarkersColor:        sparklineColorToHex(group.ColorMarkers),
+		FirstColor:          sparklineColorToHex(group.ColorFirst),
+		LastColor:           sparklineColorToHex(group.ColorLast),
+		HighColor:           sparklineColorToHex(group.ColorHigh),
+		LowColor:            sparklineColorToHex(group.ColorLow),
+		DateAxisRange:       group.F,
+		MinAxisType:         group.MinAxisType,
+		MaxAxisType:         group.MaxAxisType,
+		CustomMin:           group.ManualMin,
+		CustomMax:           group.ManualMax,
+		DisplayEmptyCellsAs: sparklineDisplayEmptyCellsAsOption(group.DisplayEmptyCellsAs),
+		ShowMarkers:         group.Markers,
+		RightToLeft:         group.RightToLeft,
+		Group:               group.MinAxisType == SparklineAxisTypeGroup || group.MaxAxisType == SparklineAxisTypeGroup,
+	}
+	for _, spark := range group.Sparklines.Sparkline {
+		opts.Location = append(opts.Location, spark.Sqref)
+		opts.Range = append(opts.Range, spark.F)
+	}
+	return opts
+}
+
+// sparklineColorToHex returns the RGB hex color code of a decoded
+// sparkline color, or an empty string when the color was resolved from a
+// built-in style's theme color rather than a custom RGB value.
+func sparklineColorToHex(color *decodeSparklineColor) string {
+	if color == nil || color.RGB == "" {
+		return ""
+	}
+	return "#" + strings.TrimPrefix(strings.ToUpper(color.RGB), "FF")
+}
+
+// DeleteSparkline removes the sparkline anchored at the given cell on the
+// given worksheet. When its sparkline group becomes empty, the group
+// itself, and the x14:sparklineGroups extension if it was the last
+// remaining group, are removed as well.
+func (f *File) DeleteSparkline(sheet, cell string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.ExtLst == nil {
+		return newInvalidSparklineCellError(cell)
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for extIdx, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISparklineGroups {
+			continue
+		}
+		decoded := new(decodeWorksheetSparklineGroups)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decoded); err != nil && err != io.EOF {
+			return err
+		}
+		for groupIdx, group := range decoded.SparklineGroup {
+			for sparkIdx, spark := range group.Sparklines.Sparkline {
+				if spark.Sqref != cell {
+					continue
+				}
+				group.Sparklines.Sparkline = append(group.Sparklines.Sparkline[:sparkIdx], group.Sparklines.Sparkline[sparkIdx+1:]...)
+				if len(group.Sparklines.Sparkline) == 0 {
+					decoded.SparklineGroup = append(decoded.SparklineGroup[:groupIdx], decoded.SparklineGroup[groupIdx+1:]...)
+				} else {
+					decoded.SparklineGroup[groupIdx] = group
+				}
+				if len(decoded.SparklineGroup) == 0 {
+					decodeExtLst.Ext = append(decodeExtLst.Ext[:extIdx], decodeExtLst.Ext[extIdx+1:]...)
+				} else {
+					var content string
+					for _, g := range decoded.SparklineGroup {
+						groupBytes, err := xml.Marshal(sparklineGroupFromDecoded(g))
+						if err != nil {
+							return err
+						}
+						content += string(groupBytes)
+					}
+					groupsBytes, err := xml.Marshal(xlsxX14SparklineGroupsExt{XMLNSXM: NameSpaceSpreadSheetXM.Value, Content: content})
+					if err != nil {
+						return err
+					}
+					decodeExtLst.Ext[extIdx].Content = string(groupsBytes)
+				}
+				extLstBytes, err := xml.Marshal(decodeExtLst)
+				if err != nil {
+					return err
+				}
+				if len(decodeExtLst.Ext) == 0 {
+					ws.ExtLst = nil
+				} else {
+					ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+				}
+				return nil
+			}
+		}
+	}
+	return newInvalidSparklineCellError(cell)
+}
+
+// convertDecodedSparklineColor converts a decoded sparkline color back
+// into its marshalable representation.
+func convertDecodedSparklineColor(color *decodeSparklineColor) *xlsxX14SparklineColor {
+	if color == nil {
+		return nil
+	}
+	return &xlsxX14SparklineColor{Theme: color.Theme, Tint: color.Tint, RGB: color.RGB}
+}
+
+// sparklineGroupFromDecoded converts a decoded x14:sparklineGroup back
+// into its marshalable representation, so that it can be rewritten after
+// one of its sparklines was removed.
+func sparklineGroupFromDecoded(group decodeWorksheetSparklineGroup) *xlsxX14SparklineGroup {
+	result := &xlsxX14SparklineGroup{
+		ManualMax: group.ManualMax, ManualMin: group.ManualMin, Type: group.Type, F: group.F,
+		DateAxis: group.DateAxis, DisplayEmptyCellsAs: group.DisplayEmptyCellsAs,
+		Markers: group.Markers, High: group.High, Low: group.Low, First: group.First, Last: group.Last,
+		Negative: group.Negative, DisplayXAxis: group.DisplayXAxis, DisplayHidden: group.DisplayHidden,
+		MinAxisType: group.MinAxisType, MaxAxisType: group.MaxAxisType, RightToLeft: group.RightToLeft,
+		ColorSeries:   convertDecodedSparklineColor(group.ColorSeries),
+		ColorNegative: convertDecodedSparklineColor(group.ColorNegative),
+		ColorAxis:     convertDecodedSparklineColor(group.ColorAxis),
+		ColorMarkers:  convertDecodedSparklineColor(group.ColorMarkers),
+		ColorFirst:    convertDecodedSparklineColor(group.ColorFirst),
+		ColorLast:     convertDecodedSparklineColor(group.ColorLast),
+		ColorHigh:     convertDecodedSparklineColor(group.ColorHigh),
+		ColorLow:      convertDecodedSparklineColor(group.ColorLow),
+	}
+	for _, spark := range group.Sparklines.Sparkline {
+		result.Sparklines.Sparkline = append(result.Sparklines.Sparkline, xlsxX14Sparkline{F: spark.F, Sqref: spark.Sqref})
+	}
+	return result
+}
+
+// newInvalidSparklineTypeError defines an error for an invalid sparkline
+// type.
+func newInvalidSparklineTypeError(sparklineType string) error {
+	return fmt.Errorf("sparkline type %q is invalid", sparklineType)
+}
+
+// newInvalidSparklineStyleError defines an error for an invalid sparkline
+// style index, which must be between 0 and 35 inclusive.
+func newInvalidSparklineStyleError(style int) error {
+	return fmt.Errorf("sparkline style %d is invalid, it should be between 0 and 35", style)
+}
+
+// newInvalidSparklineAxisTypeError defines an error for an invalid
+// MinAxisType or MaxAxisType value given to AddSparklineGroup.
+func newInvalidSparklineAxisTypeError(axisType string) error {
+	return fmt.Errorf("sparkline axis type %q is invalid", axisType)
+}
+
+// newInvalidSparklineEmptyCellsAsError defines an error for an invalid
+// DisplayEmptyCellsAs value given to AddSparklineGroup.
+func newInvalidSparklineEmptyCellsAsError(displayEmptyCellsAs string) error {
+	return fmt.Errorf("sparkline empty cells option %q is invalid", displayEmptyCellsAs)
+}
+
+// newInvalidSparklineCellError defines an error for a cell that has no
+// sparkline anchored to it.
+func newInvalidSparklineCellError(cell string) error {
+	return fmt.Errorf("no sparkline found in cell %q", cell)
+}
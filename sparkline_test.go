@@ -0,0 +1,81 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package excelize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSparklineGroup(t *testing.T) {
+	f := NewFile()
+	require.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	require.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{3, 2, 1}))
+
+	require.NoError(t, f.AddSparklineGroup("Sheet1", &SparklineOptions{
+		Location:  []string{"D1", "D2"},
+		Range:     []string{"Sheet1!A1:C1", "Sheet1!A2:C2"},
+		Type:      SparklineTypeColumn,
+		Style:     8,
+		HighColor: "FF0000",
+	}))
+
+	groups, err := f.GetSparklineGroups("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, SparklineTypeColumn, groups[0].Type)
+	assert.Equal(t, []string{"D1", "D2"}, groups[0].Location)
+	assert.Equal(t, []string{"Sheet1!A1:C1", "Sheet1!A2:C2"}, groups[0].Range)
+
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "D1"))
+	groups, err = f.GetSparklineGroups("Sheet1")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, []string{"D2"}, groups[0].Location)
+
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "D2"))
+	groups, err = f.GetSparklineGroups("Sheet1")
+	require.NoError(t, err)
+	assert.Len(t, groups, 0)
+
+	assert.Error(t, f.DeleteSparkline("Sheet1", "D2"))
+}
+
+func TestAddSparklineGroupInvalidOptions(t *testing.T) {
+	f := NewFile()
+	require.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+
+	assert.Error(t, f.AddSparklineGroup("Sheet1", &SparklineOptions{
+		Location:    []string{"D1"},
+		Range:       []string{"Sheet1!A1:C1"},
+		MinAxisType: "custom_typo",
+	}))
+	assert.Error(t, f.AddSparklineGroup("Sheet1", &SparklineOptions{
+		Location:    []string{"D1"},
+		Range:       []string{"Sheet1!A1:C1"},
+		MaxAxisType: "custom_typo",
+	}))
+	assert.Error(t, f.AddSparklineGroup("Sheet1", &SparklineOptions{
+		Location:            []string{"D1"},
+		Range:               []string{"Sheet1!A1:C1"},
+		DisplayEmptyCellsAs: "custom_typo",
+	}))
+}
+
+func TestNewSparklineGroupHighlightFlags(t *testing.T) {
+	group := newSparklineGroup(&SparklineOptions{
+		Location:  []string{"A1"},
+		Range:     []string{"Sheet1!A1:C1"},
+		Type:      SparklineTypeLine,
+		HighColor: "FF0000",
+		LowColor:  "00FF00",
+	})
+	assert.True(t, group.High)
+	assert.True(t, group.Low)
+	assert.NotNil(t, group.ColorHigh)
+	assert.NotNil(t, group.ColorLow)
+}